@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	sdk "github.com/google/go-github/v36/github"
+	gc "github.com/opensourceways/community-robot-lib/githubclient"
+)
+
+var endsWithPunctuationRe = regexp.MustCompile(`[.,;:!?]$`)
+
+// getCommitLintIssues checks each commit's subject line (the first line of its
+// message) against cfg.CommitLint's rules and returns, for each violating
+// commit, its sha mapped to a description of what's wrong with it. Merge
+// commits are not linted.
+func getCommitLintIssues(commits []*sdk.RepositoryCommit, cfg *botConfig) map[string]string {
+	violations := make(map[string]string)
+
+	var prefixRe *regexp.Regexp
+	if cfg.CommitLint.RequiredPrefixRegex != "" {
+		prefixRe = regexp.MustCompile(cfg.CommitLint.RequiredPrefixRegex)
+	}
+
+	maxLen := cfg.CommitLint.maxSubjectLength()
+
+	for _, c := range commits {
+		if isMergeCommit(c) {
+			continue
+		}
+
+		subject := commitSubject(c.GetCommit().GetMessage())
+
+		if reasons := lintSubject(subject, maxLen, prefixRe); len(reasons) > 0 {
+			violations[c.GetSHA()] = fmt.Sprintf("%s (%s)", subject, strings.Join(reasons, "; "))
+		}
+	}
+
+	return violations
+}
+
+func commitSubject(message string) string {
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		return message[:i]
+	}
+
+	return message
+}
+
+func lintSubject(subject string, maxLen int, prefixRe *regexp.Regexp) []string {
+	var reasons []string
+
+	if len(subject) > maxLen {
+		reasons = append(reasons, fmt.Sprintf("exceeds %d characters", maxLen))
+	}
+
+	if r := firstRune(subject); r != 0 && !unicode.IsUpper(r) {
+		reasons = append(reasons, "must start with an uppercase letter")
+	}
+
+	if endsWithPunctuationRe.MatchString(subject) {
+		reasons = append(reasons, "must not end with punctuation")
+	}
+
+	if prefixRe != nil && !prefixRe.MatchString(subject) {
+		reasons = append(reasons, "must match the required prefix")
+	}
+
+	return reasons
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+
+	return 0
+}
+
+func commitLintGuideHeader() string {
+	return "Your commit messages could use a little cleanup before this can be merged:"
+}
+
+func commitLintGuide(issues map[string]string) string {
+	s := "%s\n\n%s\n\nPlease amend the commits (e.g. `git commit --amend` or an interactive rebase) and force-push to update this pull request."
+
+	return fmt.Sprintf(s, commitLintGuideHeader(), generateUnSignComment(issues))
+}
+
+func deleteCommitLintGuide(pr gc.PRInfo, c iClient) {
+	v, err := c.GetPRComments(pr)
+	if err != nil {
+		return
+	}
+
+	header := commitLintGuideHeader()
+
+	for i := range v {
+		if item := v[i]; strings.HasPrefix(item.GetBody(), header) {
+			_ = c.DeletePRComment(pr.Org, pr.Repo, item.GetID())
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestLintSubject(t *testing.T) {
+	prefixRe := regexp.MustCompile(`^(Fix|Feat|Docs): `)
+
+	cases := []struct {
+		name    string
+		subject string
+		maxLen  int
+		prefix  *regexp.Regexp
+		wantOK  bool
+	}{
+		{"valid subject", "Correct the thing", 72, nil, true},
+		{"too long", "Correct the thing", 5, nil, false},
+		{"must start with an uppercase letter", "correct the thing", 72, nil, false},
+		{"must not end with punctuation", "Correct the thing.", 72, nil, false},
+		{"matches required prefix", "Fix: correct the thing", 72, prefixRe, true},
+		{"missing required prefix", "Correct the thing", 72, prefixRe, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reasons := lintSubject(c.subject, c.maxLen, c.prefix)
+
+			if ok := len(reasons) == 0; ok != c.wantOK {
+				t.Fatalf("got reasons=%v, want ok=%v", reasons, c.wantOK)
+			}
+		})
+	}
+}
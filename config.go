@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/opensourceways/community-robot-lib/config"
+	"github.com/opensourceways/community-robot-lib/utils"
+)
+
+type configuration struct {
+	ConfigItems []botConfig `json:"config_items,omitempty"`
+}
+
+func (c *configuration) Validate() error {
+	if c == nil {
+		return fmt.Errorf("configuration is nil")
+	}
+
+	items := make([]config.IRepoFilter, len(c.ConfigItems))
+	for i := range c.ConfigItems {
+		items[i] = &c.ConfigItems[i]
+	}
+
+	return config.ValidateItems(items)
+}
+
+func (c *configuration) configFor(org, repo string) *botConfig {
+	if c == nil {
+		return nil
+	}
+
+	items := make([]config.IRepoFilter, len(c.ConfigItems))
+	for i := range c.ConfigItems {
+		items[i] = &c.ConfigItems[i]
+	}
+
+	i := config.Find(org, repo, items)
+	if i < 0 {
+		return nil
+	}
+
+	return &c.ConfigItems[i]
+}
+
+// StatusMode controls how the CLA result is surfaced on a pull request.
+type StatusMode string
+
+const (
+	statusModeLabel  StatusMode = "label"
+	statusModeStatus StatusMode = "status"
+	statusModeBoth   StatusMode = "both"
+)
+
+func (m StatusMode) reportsLabel() bool {
+	return m == "" || m == statusModeLabel || m == statusModeBoth
+}
+
+func (m StatusMode) reportsStatus() bool {
+	return m == statusModeStatus || m == statusModeBoth
+}
+
+// Backend names selectable via botConfig.BackendType. The empty value and
+// signatureBackendHTTP both mean "check against CheckURL", preserving the bot's
+// original behavior for repositories that don't set BackendType.
+const (
+	signatureBackendHTTP         = "http"
+	signatureBackendGitHubUsers  = "github-usernames"
+	signatureBackendCorporateCLA = "corporate"
+)
+
+type botConfig struct {
+	config.RepoFilter
+
+	// CLALabelYes is the label name added to the pull requests whose commits have all signed the CLA.
+	CLALabelYes string `json:"cla_label_yes" required:"true"`
+
+	// CLALabelNo is the label name added to the pull requests which have one commit at least not signing the CLA.
+	CLALabelNo string `json:"cla_label_no" required:"true"`
+
+	// CheckURL is the website address of checking whether the email has signed CLA.
+	CheckURL string `json:"check_url" required:"true"`
+
+	// SignURL is the website address of signing CLA.
+	SignURL string `json:"sign_url" required:"true"`
+
+	// FAQURL is the website address of answering common questions about CLA signing.
+	FAQURL string `json:"faq_url" required:"true"`
+
+	// CheckByCommitter decides whether checking CLA by the committer instead of the author.
+	CheckByCommitter bool `json:"check_by_committer"`
+
+	// LitePRCommitter is the committer used by the lite pull request.
+	LitePRCommitter litePRCommitter `json:"lite_pr_committer"`
+
+	// StatusMode decides how the CLA result is reported on a pull request: by label,
+	// by a GitHub commit status, or both. It defaults to "label" when not set.
+	StatusMode StatusMode `json:"status_mode,omitempty"`
+
+	// StatusContext is the context name used when reporting the CLA result as a commit status.
+	StatusContext string `json:"status_context,omitempty"`
+
+	// BackendType selects the signature.Backend used to verify CLA signatures:
+	// "http" (default) checks CheckURL, "github-usernames" checks a signers file
+	// kept in GitHubSigners, and "corporate" trusts CorporateCLA's approved domains.
+	BackendType string `json:"backend_type,omitempty"`
+
+	// GitHubSigners configures the "github-usernames" backend.
+	GitHubSigners gitHubSignersConfig `json:"github_signers,omitempty"`
+
+	// CorporateCLA configures the "corporate" backend.
+	CorporateCLA corporateCLAConfig `json:"corporate_cla,omitempty"`
+
+	// DCOCheck enables the DCO (Signed-off-by) sub-check alongside the CLA check.
+	DCOCheck bool `json:"dco_check,omitempty"`
+
+	// DCOLabelNo is the label applied while a pull request has commits missing a
+	// valid DCO sign-off. Required when DCOCheck is enabled.
+	DCOLabelNo string `json:"dco_label_no,omitempty"`
+
+	// DCOSkipBots lists GitHub logins whose commits are exempt from the DCO check,
+	// e.g. automated merge or dependency-bump bots.
+	DCOSkipBots []string `json:"dco_skip_bots,omitempty"`
+
+	// CommitLint configures the optional commit-message linting sub-feature.
+	CommitLint commitLintConfig `json:"commit_linting,omitempty"`
+
+	// CheckTimeout bounds, in seconds, how long a single call to the CLA-check
+	// backend may take. Defaults to 10s when zero.
+	CheckTimeout int `json:"check_timeout,omitempty"`
+
+	// CheckRetries is the maximum number of attempts (including the first) the
+	// HTTP backend makes against CheckURL before giving up. Defaults to 3 when
+	// zero.
+	CheckRetries int `json:"check_retries,omitempty"`
+
+	// CheckCacheTTL is how long, in seconds, a signed/unsigned result is cached
+	// per email so repeated PR events don't re-hit the upstream service.
+	// Defaults to 300s when zero; a negative value disables the cache.
+	CheckCacheTTL int `json:"check_cache_ttl,omitempty"`
+
+	// RequiredLabelRegex, when set, enables the "required label" gate: a pull
+	// request must carry a label matching this regex (e.g. "^kind/") before its
+	// CLA result is treated as final.
+	RequiredLabelRegex string `json:"required_label_regex,omitempty"`
+
+	// MissingLabel is applied while no label on the pull request matches
+	// RequiredLabelRegex. Defaults to "needs-triage" when empty.
+	MissingLabel string `json:"missing_label,omitempty"`
+
+	// MissingLabelComment is posted alongside MissingLabel. Defaults to a
+	// generic message referencing RequiredLabelRegex when empty.
+	MissingLabelComment string `json:"missing_label_comment,omitempty"`
+
+	// GracePeriod is how long, in seconds, a newly opened pull request is given
+	// to acquire a matching label before MissingLabel is applied.
+	GracePeriod int `json:"grace_period,omitempty"`
+}
+
+func (c *botConfig) requiredLabelRegexp() (*regexp.Regexp, bool) {
+	if c.RequiredLabelRegex == "" {
+		return nil, false
+	}
+
+	return regexp.MustCompile(c.RequiredLabelRegex), true
+}
+
+func (c *botConfig) missingLabel() string {
+	if c.MissingLabel != "" {
+		return c.MissingLabel
+	}
+
+	return "needs-triage"
+}
+
+func (c *botConfig) gracePeriod() time.Duration {
+	return time.Duration(c.GracePeriod) * time.Second
+}
+
+func (c *botConfig) checkTimeout() time.Duration {
+	if c.CheckTimeout > 0 {
+		return time.Duration(c.CheckTimeout) * time.Second
+	}
+
+	return 10 * time.Second
+}
+
+func (c *botConfig) checkRetries() int {
+	if c.CheckRetries > 0 {
+		return c.CheckRetries
+	}
+
+	return 3
+}
+
+func (c *botConfig) checkCacheTTL() time.Duration {
+	if c.CheckCacheTTL < 0 {
+		return 0
+	}
+
+	if c.CheckCacheTTL > 0 {
+		return time.Duration(c.CheckCacheTTL) * time.Second
+	}
+
+	return 5 * time.Minute
+}
+
+type commitLintConfig struct {
+	// Enable turns the commit-message linting sub-feature on for this repo.
+	Enable bool `json:"enable,omitempty"`
+
+	// MaxSubjectLength is the maximum allowed length of a commit subject line.
+	// Defaults to 72 when zero.
+	MaxSubjectLength int `json:"max_subject_length,omitempty"`
+
+	// RequiredPrefixRegex, when set, is a regexp the commit subject must match,
+	// e.g. a conventional-commits prefix like `^(feat|fix|docs)(\(.+\))?: `.
+	RequiredPrefixRegex string `json:"required_prefix_regex,omitempty"`
+
+	// Label is the label applied while a pull request has commit-lint violations.
+	// Defaults to "commit-lint/no" when empty.
+	Label string `json:"label,omitempty"`
+}
+
+func (c commitLintConfig) maxSubjectLength() int {
+	if c.MaxSubjectLength > 0 {
+		return c.MaxSubjectLength
+	}
+
+	return 72
+}
+
+func (c commitLintConfig) label() string {
+	if c.Label != "" {
+		return c.Label
+	}
+
+	return "commit-lint/no"
+}
+
+type gitHubSignersConfig struct {
+	// Org and Repo are where the signers file is kept.
+	Org  string `json:"org,omitempty"`
+	Repo string `json:"repo,omitempty"`
+
+	// Branch is the branch the signers file is read from, e.g. "master".
+	Branch string `json:"branch,omitempty"`
+
+	// Path is the signers file path within the repo, e.g. "signers.yaml".
+	Path string `json:"path,omitempty"`
+}
+
+type corporateCLAConfig struct {
+	// ApprovedDomains maps an approved company domain to the identities (email or
+	// GitHub login) allowed to contribute on its behalf. An empty list approves
+	// every address at that domain.
+	ApprovedDomains map[string][]string `json:"approved_domains,omitempty"`
+}
+
+func (c *botConfig) Validate() error {
+	if err := c.RepoFilter.Validate(); err != nil {
+		return err
+	}
+
+	if err := utils.CheckRequired(c); err != nil {
+		return err
+	}
+
+	if c.DCOCheck && c.DCOLabelNo == "" {
+		return fmt.Errorf("dco_label_no must be set when dco_check is enabled")
+	}
+
+	if c.CommitLint.Enable && c.CommitLint.RequiredPrefixRegex != "" {
+		if _, err := regexp.Compile(c.CommitLint.RequiredPrefixRegex); err != nil {
+			return fmt.Errorf("commit_linting.required_prefix_regex is not a valid regexp: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (c *botConfig) statusContext() string {
+	if c.StatusContext != "" {
+		return c.StatusContext
+	}
+
+	return "cla/check"
+}
+
+type litePRCommitter struct {
+	// Email is the email of the committer who commits for the lite pull requests.
+	Email string `json:"email"`
+
+	// Name is the username of the committer who commits for the lite pull requests.
+	Name string `json:"name"`
+}
+
+func (l litePRCommitter) isLitePR(email, name string) bool {
+	return l.Email != "" && l.Name != "" && l.Email == email && l.Name == name
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	sdk "github.com/google/go-github/v36/github"
+)
+
+var signedOffByRe = regexp.MustCompile(`(?mi)^Signed-off-by:\s*.+?\s*<([^<>\s]+)>\s*$`)
+
+// getDCOIssues returns, for each commit missing a valid DCO sign-off, its sha
+// mapped to its commit message. A commit is considered signed off when one of
+// its "Signed-off-by: Name <email>" trailers has an email matching the commit
+// author's, ignoring case and surrounding whitespace. Merge commits and commits
+// authored by cfg.DCOSkipBots are not checked.
+func getDCOIssues(commits []*sdk.RepositoryCommit, cfg *botConfig) map[string]string {
+	unsigned := make(map[string]string)
+
+	for _, c := range commits {
+		if isMergeCommit(c) || isSkippedDCOBot(c, cfg) {
+			continue
+		}
+
+		if !hasValidDCOSignOff(c) {
+			unsigned[c.GetSHA()] = c.GetCommit().GetMessage()
+		}
+	}
+
+	return unsigned
+}
+
+func isMergeCommit(c *sdk.RepositoryCommit) bool {
+	return len(c.Parents) > 1
+}
+
+func isSkippedDCOBot(c *sdk.RepositoryCommit, cfg *botConfig) bool {
+	login := c.GetAuthor().GetLogin()
+	if login == "" {
+		return false
+	}
+
+	for _, bot := range cfg.DCOSkipBots {
+		if strings.EqualFold(bot, login) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasValidDCOSignOff(c *sdk.RepositoryCommit) bool {
+	email := strings.ToLower(strings.TrimSpace(c.GetCommit().GetAuthor().GetEmail()))
+	if email == "" {
+		return false
+	}
+
+	for _, m := range signedOffByRe.FindAllStringSubmatch(c.GetCommit().GetMessage(), -1) {
+		if strings.ToLower(strings.TrimSpace(m[1])) == email {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dcoGuideTitle() string {
+	return "The authors of the following commits have not added a valid DCO sign-off (`Signed-off-by`):"
+}
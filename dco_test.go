@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	sdk "github.com/google/go-github/v36/github"
+)
+
+func dcoCommit(sha, email, message string, parents int) *sdk.RepositoryCommit {
+	c := &sdk.RepositoryCommit{
+		SHA: sdk.String(sha),
+		Commit: &sdk.Commit{
+			Message: sdk.String(message),
+			Author:  &sdk.CommitAuthor{Email: sdk.String(email)},
+		},
+	}
+
+	for i := 0; i < parents; i++ {
+		c.Parents = append(c.Parents, &sdk.Commit{})
+	}
+
+	return c
+}
+
+func TestGetDCOIssues(t *testing.T) {
+	commits := []*sdk.RepositoryCommit{
+		dcoCommit("s1", "a@example.com", "fix: thing\n\nSigned-off-by: A <a@example.com>", 0),
+		dcoCommit("s2", "a@example.com", "fix: other thing", 0),
+		dcoCommit("s3", "a@example.com", "fix: mismatched sign-off\n\nSigned-off-by: A <other@example.com>", 0),
+		dcoCommit("s4", "bot@example.com", "chore: merge", 2),
+	}
+	commits[3].Author = &sdk.User{Login: sdk.String("renovate-bot")}
+
+	cfg := &botConfig{DCOSkipBots: []string{"renovate-bot"}}
+
+	issues := getDCOIssues(commits, cfg)
+
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %v", len(issues), issues)
+	}
+
+	for _, sha := range []string{"s2", "s3"} {
+		if _, ok := issues[sha]; !ok {
+			t.Errorf("expected %s to be reported as missing DCO sign-off", sha)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	sdk "github.com/google/go-github/v36/github"
+	gc "github.com/opensourceways/community-robot-lib/githubclient"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// handleRequiredLabel implements a "required label" gate: once cfg.GracePeriod
+// has passed since a pull request was opened, it must carry at least one label
+// matching cfg.RequiredLabelRegex (e.g. "^kind/" or "^area/"), or the bot applies
+// cfg.MissingLabel with a guiding comment until one is added. This is the common
+// case where a CLA-passing pull request still isn't mergeable until its triage
+// metadata exists.
+//
+// It returns the pull request's current label set, reflecting any add/remove
+// of cfg.missingLabel() it just performed, so a caller that goes on to call
+// handle afterwards derives needsTriage from the up-to-date state instead of
+// the event's pre-mutation label snapshot.
+func (bot *robot) handleRequiredLabel(e *sdk.PullRequestEvent, info gc.IssuePRInfo, cfg *botConfig, log *logrus.Entry) (sets.String, error) {
+	labels := info.GetLabels()
+
+	re, ok := cfg.requiredLabelRegexp()
+	if !ok {
+		return labels, nil
+	}
+
+	org, repo := info.GetOrgRepo()
+	pr := gc.PRInfo{Org: org, Repo: repo, Number: info.GetNumber()}
+	hasMissingLabel := labels.Has(cfg.missingLabel())
+
+	if hasMatchingLabel(labels, re) {
+		if hasMissingLabel {
+			if err := bot.cli.RemovePRLabel(pr, cfg.missingLabel()); err != nil {
+				return labels, err
+			}
+
+			labels = withMissingLabel(labels, cfg, false)
+		}
+
+		deleteMissingLabelGuide(pr, bot.cli)
+
+		return labels, nil
+	}
+
+	if time.Since(e.GetPullRequest().GetCreatedAt()) < cfg.gracePeriod() {
+		return labels, nil
+	}
+
+	if hasMissingLabel {
+		return labels, nil
+	}
+
+	if err := bot.cli.AddPRLabel(pr, cfg.missingLabel()); err != nil {
+		return labels, err
+	}
+
+	if err := bot.cli.CreatePRComment(pr, missingLabelGuide(cfg)); err != nil {
+		return labels, err
+	}
+
+	return withMissingLabel(labels, cfg, true), nil
+}
+
+// withMissingLabel returns a copy of labels with cfg.missingLabel() inserted or
+// deleted, leaving the original set (which may be shared with the event's own
+// cached copy) untouched.
+func withMissingLabel(labels sets.String, cfg *botConfig, present bool) sets.String {
+	updated := sets.NewString(labels.List()...)
+
+	if present {
+		updated.Insert(cfg.missingLabel())
+	} else {
+		updated.Delete(cfg.missingLabel())
+	}
+
+	return updated
+}
+
+func hasMatchingLabel(labels sets.String, re *regexp.Regexp) bool {
+	for _, l := range labels.List() {
+		if re.MatchString(l) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func missingLabelGuideHeader() string {
+	return "This pull request is missing required metadata:"
+}
+
+func missingLabelGuide(cfg *botConfig) string {
+	msg := cfg.MissingLabelComment
+	if msg == "" {
+		msg = fmt.Sprintf(
+			"Please add a label matching `%s` (e.g. a `kind/*` or `area/*` label) so this pull request can be triaged.",
+			cfg.RequiredLabelRegex,
+		)
+	}
+
+	return fmt.Sprintf("%s\n\n%s", missingLabelGuideHeader(), msg)
+}
+
+func deleteMissingLabelGuide(pr gc.PRInfo, c iClient) {
+	v, err := c.GetPRComments(pr)
+	if err != nil {
+		return
+	}
+
+	header := missingLabelGuideHeader()
+
+	for i := range v {
+		if item := v[i]; strings.HasPrefix(item.GetBody(), header) {
+			_ = c.DeletePRComment(pr.Org, pr.Repo, item.GetID())
+		}
+	}
+}
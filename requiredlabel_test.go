@@ -0,0 +1,49 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestWithMissingLabel(t *testing.T) {
+	cfg := &botConfig{MissingLabel: "needs-triage"}
+	original := sets.NewString("area/docs")
+
+	added := withMissingLabel(original, cfg, true)
+	if !added.Has("needs-triage") {
+		t.Fatalf("expected needs-triage to be inserted, got %v", added.List())
+	}
+
+	if original.Has("needs-triage") {
+		t.Fatalf("withMissingLabel must not mutate its input, got %v", original.List())
+	}
+
+	removed := withMissingLabel(added, cfg, false)
+	if removed.Has("needs-triage") {
+		t.Fatalf("expected needs-triage to be deleted, got %v", removed.List())
+	}
+}
+
+func TestHasMatchingLabel(t *testing.T) {
+	re := regexp.MustCompile(`^kind/`)
+
+	cases := []struct {
+		name   string
+		labels sets.String
+		want   bool
+	}{
+		{"no labels", sets.NewString(), false},
+		{"no matching label", sets.NewString("needs-triage", "area/docs"), false},
+		{"matching label", sets.NewString("kind/bug", "area/docs"), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := hasMatchingLabel(c.labels, re); got != c.want {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
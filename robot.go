@@ -1,19 +1,23 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	sdk "github.com/google/go-github/v36/github"
 	"github.com/opensourceways/community-robot-lib/config"
 	gc "github.com/opensourceways/community-robot-lib/githubclient"
 	framework "github.com/opensourceways/community-robot-lib/robot-github-framework"
 	"github.com/opensourceways/community-robot-lib/utils"
+	"github.com/opensourceways/robot-github-cla/signature"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -30,14 +34,22 @@ type iClient interface {
 	DeletePRComment(org, repo string, ID int64) error
 	GetPRCommits(pr gc.PRInfo) ([]*sdk.RepositoryCommit, error)
 	GetPRComments(pr gc.PRInfo) ([]*sdk.IssueComment, error)
+	CreateStatus(org, repo, sha string, status sdk.RepoStatus) error
+	GetPathContent(org, repo, path, branch string) ([]byte, error)
 }
 
 func newRobot(cli iClient) *robot {
-	return &robot{cli: cli}
+	return &robot{cli: cli, backends: map[string]signature.Backend{}}
 }
 
 type robot struct {
 	cli iClient
+
+	backendsMu sync.Mutex
+	// backends caches a signature.Backend per repo configuration, so the HTTP
+	// backend's in-memory cache survives across events instead of being
+	// rebuilt, and emptied, on every pull request or /check-cla comment.
+	backends map[string]signature.Backend
 }
 
 func (bot *robot) NewConfig() config.Config {
@@ -67,11 +79,6 @@ func (bot *robot) handlePREvent(e *sdk.PullRequestEvent, c config.Config, log *l
 		return nil
 	}
 
-	v := e.GetAction()
-	if !gc.IsPROpened(v) && !gc.IsPRSourceBranchChanged(v) {
-		return nil
-	}
-
 	info := gc.GenIssuePRInfo(e)
 
 	org, repo := info.GetOrgRepo()
@@ -80,11 +87,35 @@ func (bot *robot) handlePREvent(e *sdk.PullRequestEvent, c config.Config, log *l
 		return err
 	}
 
-	_, err = bot.handle(info, cfg, log)
+	// Runs on every open-PR event so the required-label gate reacts as soon as
+	// a required label shows up, disappears, or the grace period elapses.
+	// Called directly rather than through a second RegisterPullRequestHandler,
+	// since HandlerRegister isn't guaranteed to fan a pull-request event out to
+	// more than one registered handler. Its returned labels already reflect
+	// any add/remove it just performed, so the handle call below never derives
+	// needsTriage from a stale pre-mutation label snapshot.
+	labels, err := bot.handleRequiredLabel(e, info, cfg, log)
+	if err != nil {
+		return err
+	}
+
+	v := e.GetAction()
+	if !gc.IsPROpened(v) && !gc.IsPRSourceBranchChanged(v) && !isPRLabelAction(v) {
+		return nil
+	}
+
+	_, err = bot.handle(info, labels, cfg, log)
 
 	return err
 }
 
+// isPRLabelAction reports whether v is a pull request "labeled"/"unlabeled"
+// action, the actions through which the required-label gate's state changes
+// outside of a pull request being opened or its source branch changing.
+func isPRLabelAction(v string) bool {
+	return v == "labeled" || v == "unlabeled"
+}
+
 func (bot *robot) handleNoteEvent(e *sdk.IssueCommentEvent, c config.Config, log *logrus.Entry) error {
 	if !gc.IsCommentCreated(e) || !gc.IsCommentOnPullRequest(e) {
 		return nil
@@ -103,7 +134,7 @@ func (bot *robot) handleNoteEvent(e *sdk.IssueCommentEvent, c config.Config, log
 		return err
 	}
 
-	if b, err := bot.handle(info, cfg, log); err != nil || !b {
+	if b, err := bot.handle(info, info.GetLabels(), cfg, log); err != nil || !b {
 		return err
 	}
 
@@ -117,7 +148,7 @@ func (bot *robot) handleNoteEvent(e *sdk.IssueCommentEvent, c config.Config, log
 	)
 }
 
-func (bot *robot) handle(info gc.IssuePRInfo, cfg *botConfig, log *logrus.Entry) (yes bool, err error) {
+func (bot *robot) handle(info gc.IssuePRInfo, labels sets.String, cfg *botConfig, log *logrus.Entry) (yes bool, err error) {
 	org, repo := info.GetOrgRepo()
 	pr := gc.PRInfo{
 		Org:    org,
@@ -125,18 +156,40 @@ func (bot *robot) handle(info gc.IssuePRInfo, cfg *botConfig, log *logrus.Entry)
 		Number: info.GetNumber(),
 	}
 
-	unsigned, err := bot.getUnsignedCommits(pr, cfg)
+	commits, err := bot.cli.GetPRCommits(pr)
+	if err != nil {
+		return
+	}
+
+	if len(commits) == 0 {
+		err = fmt.Errorf("commits is empty, cla cannot be checked")
+
+		return
+	}
+
+	headSHA := commits[len(commits)-1].GetSHA()
+
+	unsigned, err := bot.getUnsignedCommits(context.Background(), commits, cfg)
 	if err != nil {
 		return
 	}
 
-	labels := info.GetLabels()
+	dcoUnsigned := map[string]string{}
+	if cfg.DCOCheck {
+		dcoUnsigned = getDCOIssues(commits, cfg)
+	}
+
+	claPassed := len(unsigned) == 0
+	dcoPassed := len(dcoUnsigned) == 0
+
 	hasCLAYes := labels.Has(cfg.CLALabelYes)
 	hasCLANo := labels.Has(cfg.CLALabelNo)
+	hasDCONo := cfg.DCOCheck && labels.Has(cfg.DCOLabelNo)
+	needsTriage := cfg.RequiredLabelRegex != "" && labels.Has(cfg.missingLabel())
 
 	deleteSignGuide(pr, bot.cli)
 
-	if len(unsigned) == 0 {
+	if claPassed {
 		if hasCLANo {
 			if err = bot.cli.RemovePRLabel(pr, cfg.CLALabelNo); err != nil {
 				err = fmt.Errorf(
@@ -148,72 +201,165 @@ func (bot *robot) handle(info gc.IssuePRInfo, cfg *botConfig, log *logrus.Entry)
 			}
 		}
 
-		if !hasCLAYes {
-			yes = true
-
+		if !hasCLAYes && !needsTriage && cfg.StatusMode.reportsLabel() {
 			if err = bot.cli.AddPRLabel(pr, cfg.CLALabelYes); err != nil {
 				err = fmt.Errorf(
 					"Could not add %s label, err: %s",
 					cfg.CLALabelYes, err.Error(),
 				)
+
+				return
 			}
 		}
 
-		return
-	}
+		yes = !hasCLAYes && dcoPassed && !needsTriage
+	} else {
+		if hasCLAYes {
+			if err = bot.cli.RemovePRLabel(pr, cfg.CLALabelYes); err != nil {
+				err = fmt.Errorf(
+					"Could not remove %s label, err: %s",
+					cfg.CLALabelYes, err.Error(),
+				)
 
-	if hasCLAYes {
-		if err = bot.cli.RemovePRLabel(pr, cfg.CLALabelYes); err != nil {
-			err = fmt.Errorf(
-				"Could not remove %s label, err: %s",
-				cfg.CLALabelYes, err.Error(),
-			)
+				return
+			}
+		}
 
-			return
+		if !hasCLANo && cfg.StatusMode.reportsLabel() {
+			if e := bot.cli.AddPRLabel(pr, cfg.CLALabelNo); e != nil {
+				log.WithError(e).Warningf("Could not add %s label.", cfg.CLALabelNo)
+			}
 		}
 	}
 
-	if !hasCLANo {
-		if err := bot.cli.AddPRLabel(pr, cfg.CLALabelNo); err != nil {
-			log.WithError(err).Warningf("Could not add %s label.", cfg.CLALabelNo)
+	if cfg.DCOCheck {
+		if dcoPassed && hasDCONo {
+			if e := bot.cli.RemovePRLabel(pr, cfg.DCOLabelNo); e != nil {
+				log.WithError(e).Warningf("Could not remove %s label.", cfg.DCOLabelNo)
+			}
+		} else if !dcoPassed && !hasDCONo {
+			if e := bot.cli.AddPRLabel(pr, cfg.DCOLabelNo); e != nil {
+				log.WithError(e).Warningf("Could not add %s label.", cfg.DCOLabelNo)
+			}
 		}
 	}
 
-	err = bot.cli.CreatePRComment(
-		pr, signGuide(cfg.SignURL, generateUnSignComment(unsigned), cfg.FAQURL),
-	)
+	if e := bot.handleCommitLint(pr, commits, labels, cfg, log); e != nil {
+		log.WithError(e).Warning("Could not run commit-message linting.")
+	}
+
+	bot.reportStatus(org, repo, headSHA, cfg, claPassed, dcoPassed, needsTriage, log)
+	bot.logSignatureBackendMetrics(cfg, log)
+
+	if claPassed && dcoPassed {
+		return
+	}
+
+	err = bot.cli.CreatePRComment(pr, combinedGuide(cfg, unsigned, dcoUnsigned))
 
 	return
 }
 
-func (bot *robot) getUnsignedCommits(pr gc.PRInfo, cfg *botConfig) (map[string]string, error) {
-	commits, err := bot.cli.GetPRCommits(pr)
-	if err != nil {
-		return nil, err
+// handleCommitLint checks the commit subjects of pr against cfg.CommitLint's
+// rules, reusing the same commits already fetched for the CLA/DCO checks to
+// avoid a duplicate API call, and syncs the dedicated lint comment and label.
+func (bot *robot) handleCommitLint(pr gc.PRInfo, commits []*sdk.RepositoryCommit, labels sets.String, cfg *botConfig, log *logrus.Entry) error {
+	if !cfg.CommitLint.Enable {
+		return nil
 	}
 
-	if len(commits) == 0 {
-		return nil, fmt.Errorf("commits is empty, cla cannot be checked")
+	deleteCommitLintGuide(pr, bot.cli)
+
+	issues := getCommitLintIssues(commits, cfg)
+	label := cfg.CommitLint.label()
+	hasLabel := labels.Has(label)
+
+	if len(issues) == 0 {
+		if hasLabel {
+			return bot.cli.RemovePRLabel(pr, label)
+		}
+
+		return nil
+	}
+
+	if !hasLabel {
+		if err := bot.cli.AddPRLabel(pr, label); err != nil {
+			log.WithError(err).Warningf("Could not add %s label.", label)
+		}
+	}
+
+	return bot.cli.CreatePRComment(pr, commitLintGuide(issues))
+}
+
+// reportStatus publishes the CLA result as a GitHub commit status on the PR's head
+// SHA, when the repository has opted into status-based reporting via
+// cfg.StatusMode. While needsTriage is set, the status is held at "pending"
+// regardless of claPassed/dcoPassed, deferring a final result until the pull
+// request's required label gate is satisfied.
+func (bot *robot) reportStatus(org, repo, sha string, cfg *botConfig, claPassed, dcoPassed, needsTriage bool, log *logrus.Entry) {
+	if !cfg.StatusMode.reportsStatus() || sha == "" {
+		return
 	}
 
+	state, desc := statusFor(claPassed, dcoPassed, needsTriage)
+
+	status := sdk.RepoStatus{
+		State:       sdk.String(state),
+		Context:     sdk.String(cfg.statusContext()),
+		Description: sdk.String(desc),
+		TargetURL:   sdk.String(cfg.SignURL),
+	}
+
+	if err := bot.cli.CreateStatus(org, repo, sha, status); err != nil {
+		log.WithError(err).Warningf("Could not create %s status.", cfg.statusContext())
+	}
+}
+
+// statusFor derives the commit-status state and description from the outcome
+// of the CLA check, the DCO check and the required-label gate. needsTriage
+// takes priority over both checks since their result isn't final yet; a failed
+// DCO check is reported as such rather than mislabeled as a CLA failure.
+func statusFor(claPassed, dcoPassed, needsTriage bool) (state, desc string) {
+	switch {
+	case needsTriage:
+		return "pending", "Waiting for required pull request labels before the CLA result is final."
+	case claPassed && dcoPassed:
+		return "success", "All commit authors have signed the CLA."
+	case !claPassed:
+		return "failure", "Not all commit authors have signed the CLA."
+	default:
+		return "failure", "Not all commits have a valid DCO sign-off."
+	}
+}
+
+func (bot *robot) getUnsignedCommits(ctx context.Context, commits []*sdk.RepositoryCommit, cfg *botConfig) (map[string]string, error) {
 	unsigned := make(map[string]string)
 	update := func(c *sdk.RepositoryCommit) {
 		unsigned[c.GetSHA()] = c.GetCommit().GetMessage()
 	}
 
+	backend := bot.signatureBackend(cfg)
 	result := map[string]bool{}
 
 	for i := range commits {
 		c := commits[i]
 		email := strings.Trim(getAuthorOfCommit(c, cfg), " ")
 
-		if !utils.IsValidEmail(email) {
+		identity := signature.Identity{
+			Email: email,
+			Login: c.GetAuthor().GetLogin(),
+			Name:  c.GetCommit().GetAuthor().GetName(),
+			SHA:   c.GetSHA(),
+		}
+
+		key, checkable := signatureCacheKey(cfg, identity)
+		if !checkable {
 			update(c)
 
 			continue
 		}
 
-		if v, ok := result[email]; ok {
+		if v, ok := result[key]; ok {
 			if !v {
 				update(c)
 			}
@@ -221,12 +367,15 @@ func (bot *robot) getUnsignedCommits(pr gc.PRInfo, cfg *botConfig) (map[string]s
 			continue
 		}
 
-		b, err := isSigned(email, cfg.CheckURL)
+		callCtx, cancel := context.WithTimeout(ctx, cfg.checkTimeout())
+		b, err := backend.IsSigned(callCtx, identity)
+		cancel()
+
 		if err != nil {
 			return nil, err
 		}
 
-		result[email] = b
+		result[key] = b
 		if !b {
 			update(c)
 		}
@@ -239,51 +388,147 @@ func (bot *robot) getUnsignedCommits(pr gc.PRInfo, cfg *botConfig) (map[string]s
 	return unsigned, nil
 }
 
-func getAuthorOfCommit(c *sdk.RepositoryCommit, cfg *botConfig) string {
-	if c == nil {
-		return ""
+// signatureBackend returns the signature.Backend selected by cfg.BackendType,
+// reusing a previously built one when the relevant config hasn't changed so the
+// HTTP backend's cache and retry state survive across events.
+func (bot *robot) signatureBackend(cfg *botConfig) signature.Backend {
+	key := signatureBackendCacheKey(cfg)
+
+	bot.backendsMu.Lock()
+	defer bot.backendsMu.Unlock()
+
+	if b, ok := bot.backends[key]; ok {
+		return b
 	}
 
-	if cfg.CheckByCommitter {
-		v := c.GetCommit().GetCommitter()
+	b := newSignatureBackend(bot.cli, cfg)
+	bot.backends[key] = b
 
-		if !cfg.LitePRCommitter.isLitePR(v.GetEmail(), v.GetName()) {
-			return v.GetEmail()
-		}
+	return b
+}
+
+// logSignatureBackendMetrics logs the current cache hit rate and average
+// upstream latency of cfg's signature backend, for backends that report them.
+func (bot *robot) logSignatureBackendMetrics(cfg *botConfig, log *logrus.Entry) {
+	mp, ok := bot.signatureBackend(cfg).(signature.MetricsProvider)
+	if !ok {
+		return
 	}
 
-	return c.GetCommit().GetAuthor().GetEmail()
+	m := mp.Metrics()
+
+	avgLatency := time.Duration(0)
+	if m.Requests > 0 {
+		avgLatency = m.TotalLatency / time.Duration(m.Requests)
+	}
+
+	log.WithFields(logrus.Fields{
+		"requests":    m.Requests,
+		"cache_hits":  m.CacheHits,
+		"avg_latency": avgLatency,
+	}).Debug("signature backend metrics")
 }
 
-func isSigned(email, url string) (bool, error) {
-	endpoint := fmt.Sprintf("%s?email=%s", url, email)
+// signatureBackendCacheKey identifies the configuration a signature.Backend was
+// built from, so signatureBackend can tell whether an existing one may be
+// reused. It must fold in every field newSignatureBackend reads for that
+// BackendType, or two repos that differ only in one of those fields would
+// wrongly share a backend built from whichever repo's config was seen first.
+func signatureBackendCacheKey(cfg *botConfig) string {
+	switch cfg.BackendType {
+	case signatureBackendGitHubUsers:
+		s := cfg.GitHubSigners
 
-	resp, err := http.Get(endpoint)
-	if err != nil {
-		return false, err
+		return strings.Join([]string{signatureBackendGitHubUsers, s.Org, s.Repo, s.Branch, s.Path}, "|")
+
+	case signatureBackendCorporateCLA:
+		return signatureBackendCorporateCLA + "|" + approvedDomainsKey(cfg.CorporateCLA.ApprovedDomains)
+
+	default:
+		return fmt.Sprintf(
+			"%s|%s|%s|%d|%s",
+			signatureBackendHTTP, cfg.CheckURL, cfg.checkTimeout(), cfg.checkRetries(), cfg.checkCacheTTL(),
+		)
+	}
+}
+
+// approvedDomainsKey deterministically serializes a corporateCLAConfig's
+// ApprovedDomains, so signatureBackendCacheKey doesn't depend on Go's
+// randomized map iteration order.
+func approvedDomainsKey(approvedDomains map[string][]string) string {
+	domains := make([]string, 0, len(approvedDomains))
+	for d := range approvedDomains {
+		domains = append(domains, d)
 	}
-	defer resp.Body.Close()
 
-	rb, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return false, err
+	sort.Strings(domains)
+
+	parts := make([]string, 0, len(domains))
+	for _, d := range domains {
+		contributors := append([]string(nil), approvedDomains[d]...)
+		sort.Strings(contributors)
+
+		parts = append(parts, d+"="+strings.Join(contributors, ","))
 	}
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return false, fmt.Errorf("response has status %q and body %q", resp.Status, string(rb))
+
+	return strings.Join(parts, ";")
+}
+
+// newSignatureBackend builds the signature.Backend selected by cfg.BackendType,
+// defaulting to the original CheckURL-based HTTP backend.
+func newSignatureBackend(cli iClient, cfg *botConfig) signature.Backend {
+	switch cfg.BackendType {
+	case signatureBackendGitHubUsers:
+		s := cfg.GitHubSigners
+
+		return signature.NewGitHubUsernamesBackend(cli, s.Org, s.Repo, s.Branch, s.Path)
+
+	case signatureBackendCorporateCLA:
+		return signature.NewCorporateCLABackend(cfg.CorporateCLA.ApprovedDomains)
+
+	default:
+		return signature.NewHTTPBackend(signature.HTTPBackendConfig{
+			URL:         cfg.CheckURL,
+			Client:      &http.Client{Timeout: cfg.checkTimeout()},
+			MaxAttempts: cfg.checkRetries(),
+			CacheTTL:    cfg.checkCacheTTL(),
+		})
 	}
+}
+
+// signatureCacheKey returns the identity field the selected backend actually
+// matches on, to dedupe repeated backend calls for the same author within a
+// pull request, and whether the commit carries that field at all. A commit
+// lacking it is treated as unsigned without ever calling the backend, since
+// e.g. the github-usernames backend can't resolve a signature from an email
+// alone, and the corporate backend can't resolve one from a login alone.
+func signatureCacheKey(cfg *botConfig, identity signature.Identity) (string, bool) {
+	switch cfg.BackendType {
+	case signatureBackendGitHubUsers:
+		return identity.Login, identity.Login != ""
 
-	type signingInfo struct {
-		Signed bool `json:"signed"`
+	case signatureBackendCorporateCLA:
+		return identity.Email, identity.Email != ""
+
+	default:
+		return identity.Email, utils.IsValidEmail(identity.Email)
 	}
-	var v struct {
-		Data signingInfo `json:"data"`
+}
+
+func getAuthorOfCommit(c *sdk.RepositoryCommit, cfg *botConfig) string {
+	if c == nil {
+		return ""
 	}
 
-	if err := json.Unmarshal(rb, &v); err != nil {
-		return false, fmt.Errorf("unmarshal failed: %s", err.Error())
+	if cfg.CheckByCommitter {
+		v := c.GetCommit().GetCommitter()
+
+		if !cfg.LitePRCommitter.isLitePR(v.GetEmail(), v.GetName()) {
+			return v.GetEmail()
+		}
 	}
 
-	return v.Data.Signed, nil
+	return c.GetCommit().GetAuthor().GetEmail()
 }
 
 func deleteSignGuide(pr gc.PRInfo, c iClient) {
@@ -292,32 +537,45 @@ func deleteSignGuide(pr gc.PRInfo, c iClient) {
 		return
 	}
 
-	prefix := signGuideTitle()
-	prefixOld := "Thanks for your pull request. Before we can look at your pull request, you'll need to sign a Contributor License Agreement (CLA)."
-	f := func(s string) bool {
-		return strings.HasPrefix(s, prefix) || strings.HasPrefix(s, prefixOld)
-	}
+	header := guideHeader()
 
 	for i := range v {
-		if item := v[i]; f(item.GetBody()) {
+		if item := v[i]; strings.HasPrefix(item.GetBody(), header) {
 			_ = c.DeletePRComment(pr.Org, pr.Repo, item.GetID())
 		}
 	}
 }
 
+func guideHeader() string {
+	return "Thanks for your pull request."
+}
+
 func signGuideTitle() string {
-	return "Thanks for your pull request.\n\nThe authors of the following commits have not signed the Contributor License Agreement (CLA):"
+	return "The authors of the following commits have not signed the Contributor License Agreement (CLA):"
 }
 
-func signGuide(signURL, cInfo, faq string) string {
+// combinedGuide reports whichever of the CLA and DCO checks failed in one PR
+// comment, each under its own section, so a contributor only needs to read one
+// comment to know everything blocking their pull request.
+func combinedGuide(cfg *botConfig, unsigned, dcoUnsigned map[string]string) string {
+	var sections []string
+
+	if len(unsigned) > 0 {
+		sections = append(sections, fmt.Sprintf("%s\n\n%s", signGuideTitle(), generateUnSignComment(unsigned)))
+	}
+
+	if len(dcoUnsigned) > 0 {
+		sections = append(sections, fmt.Sprintf("%s\n\n%s", dcoGuideTitle(), generateUnSignComment(dcoUnsigned)))
+	}
+
 	s := `%s
 
 %s
 
 Please check the [**FAQs**](%s) first.
-You can click [**here**](%s) to sign the CLA. After signing the CLA, you must comment "/check-cla" to check the CLA status again.`
+You can click [**here**](%s) to sign the CLA. After addressing the issues above, you must comment "/check-cla" to check the status again.`
 
-	return fmt.Sprintf(s, signGuideTitle(), cInfo, faq, signURL)
+	return fmt.Sprintf(s, guideHeader(), strings.Join(sections, "\n\n"), cfg.FAQURL, cfg.SignURL)
 }
 
 func alreadySigned(user string) string {
@@ -0,0 +1,62 @@
+package signature
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CorporateCLABackend treats a contributor as signed when their commit email's
+// domain belongs to a company that has signed a corporate CLA, optionally
+// narrowed to a per-domain allowlist of approved contributors. This mirrors how
+// EasyCLA distinguishes a corporate signature from an individual one.
+type CorporateCLABackend struct {
+	// approvedDomains maps an approved company domain (lower-cased, e.g.
+	// "example.com") to the identities (email or GitHub login) approved to
+	// contribute on its behalf. An empty list approves every address at that
+	// domain.
+	approvedDomains map[string][]string
+}
+
+// NewCorporateCLABackend returns a Backend backed by approvedDomains.
+func NewCorporateCLABackend(approvedDomains map[string][]string) *CorporateCLABackend {
+	domains := make(map[string][]string, len(approvedDomains))
+	for d, contributors := range approvedDomains {
+		domains[strings.ToLower(d)] = contributors
+	}
+
+	return &CorporateCLABackend{approvedDomains: domains}
+}
+
+func (b *CorporateCLABackend) IsSigned(ctx context.Context, identity Identity) (bool, error) {
+	domain := emailDomain(identity.Email)
+	if domain == "" {
+		return false, fmt.Errorf("identity has no email to resolve a company domain from")
+	}
+
+	contributors, ok := b.approvedDomains[strings.ToLower(domain)]
+	if !ok {
+		return false, nil
+	}
+
+	if len(contributors) == 0 {
+		return true, nil
+	}
+
+	for _, c := range contributors {
+		if strings.EqualFold(c, identity.Email) || strings.EqualFold(c, identity.Login) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 || i == len(email)-1 {
+		return ""
+	}
+
+	return email[i+1:]
+}
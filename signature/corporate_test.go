@@ -0,0 +1,49 @@
+package signature
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCorporateCLABackendIsSigned(t *testing.T) {
+	b := NewCorporateCLABackend(map[string][]string{
+		"example.com": {"alice@example.com", "carol"},
+		"acme.com":    nil,
+	})
+
+	cases := []struct {
+		name     string
+		identity Identity
+		signed   bool
+		wantErr  bool
+	}{
+		{"approved contributor", Identity{Email: "alice@example.com"}, true, false},
+		{"approved domain, wrong contributor", Identity{Email: "bob@example.com"}, false, false},
+		{"approved contributor by login", Identity{Email: "c.smith@example.com", Login: "CAROL"}, true, false},
+		{"domain with empty allowlist approves everyone", Identity{Email: "anyone@acme.com"}, true, false},
+		{"unapproved domain", Identity{Email: "bob@other.com"}, false, false},
+		{"no email", Identity{Login: "alice"}, false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			signed, err := b.IsSigned(context.Background(), c.identity)
+
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if signed != c.signed {
+				t.Fatalf("got signed=%v, want %v", signed, c.signed)
+			}
+		})
+	}
+}
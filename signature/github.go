@@ -0,0 +1,69 @@
+package signature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RepoFileGetter fetches the raw contents of a file at a path/branch in a repo.
+// It is satisfied by the bot's GitHub client, so this backend doesn't need to
+// depend on the github SDK directly.
+type RepoFileGetter interface {
+	GetPathContent(org, repo, path, branch string) ([]byte, error)
+}
+
+// GitHubUsernamesBackend treats a contributor as signed when their GitHub login
+// appears in a signers file (YAML or JSON, chosen by the file extension) kept in
+// a designated repo/branch, mirroring how EasyCLA tracks individual signatures
+// without requiring an external signing service.
+type GitHubUsernamesBackend struct {
+	getter RepoFileGetter
+
+	org, repo, branch, path string
+}
+
+// NewGitHubUsernamesBackend returns a Backend backed by the signers file at
+// org/repo's path on branch.
+func NewGitHubUsernamesBackend(getter RepoFileGetter, org, repo, branch, path string) *GitHubUsernamesBackend {
+	return &GitHubUsernamesBackend{getter: getter, org: org, repo: repo, branch: branch, path: path}
+}
+
+type signersFile struct {
+	Signers []string `json:"signers" yaml:"signers"`
+}
+
+func (b *GitHubUsernamesBackend) IsSigned(ctx context.Context, identity Identity) (bool, error) {
+	if identity.Login == "" {
+		return false, fmt.Errorf("identity has no GitHub login to check")
+	}
+
+	content, err := b.getter.GetPathContent(b.org, b.repo, b.path, b.branch)
+	if err != nil {
+		return false, err
+	}
+
+	var f signersFile
+	if err := unmarshalSignersFile(b.path, content, &f); err != nil {
+		return false, err
+	}
+
+	for _, login := range f.Signers {
+		if strings.EqualFold(login, identity.Login) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func unmarshalSignersFile(path string, content []byte, f *signersFile) error {
+	if strings.HasSuffix(path, ".json") {
+		return json.Unmarshal(content, f)
+	}
+
+	return yaml.Unmarshal(content, f)
+}
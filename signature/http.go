@@ -0,0 +1,253 @@
+package signature
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HTTPBackendConfig configures an HTTPBackend.
+type HTTPBackendConfig struct {
+	// URL is the CLA signing service's `?email=` endpoint.
+	URL string
+
+	// Client is the http.Client used for requests. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// MaxAttempts is the maximum number of attempts (including the first) made
+	// against URL before giving up. Defaults to 1 (no retries) when zero.
+	MaxAttempts int
+
+	// CacheTTL is how long a result is cached, keyed by normalized email. Zero
+	// disables caching.
+	CacheTTL time.Duration
+}
+
+// HTTPMetrics reports observability counters for an HTTPBackend, so operators
+// can watch cache hit rate, upstream traffic and upstream latency.
+type HTTPMetrics struct {
+	Requests  int64
+	CacheHits int64
+
+	// TotalLatency accumulates the wall-clock time spent waiting on cfg.URL,
+	// across all attempts of every IsSigned call that actually reached the
+	// network (cache hits aren't counted). Divide by Requests for an average.
+	TotalLatency time.Duration
+}
+
+type httpCacheEntry struct {
+	signed  bool
+	expires time.Time
+}
+
+// HTTPBackend checks CLA signatures against the `?email=` HTTP endpoint of an
+// external CLA signing service. It retries transient failures (5xx, network
+// errors, 429 honoring Retry-After) with exponential backoff and jitter, and
+// caches results in memory so repeated PR events (rebases, re-runs of
+// /check-cla) don't hammer the upstream service.
+type HTTPBackend struct {
+	cfg HTTPBackendConfig
+
+	mu      sync.Mutex
+	cache   map[string]httpCacheEntry
+	metrics HTTPMetrics
+}
+
+// NewHTTPBackend returns a Backend that queries cfg.URL for each email.
+func NewHTTPBackend(cfg HTTPBackendConfig) *HTTPBackend {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+
+	return &HTTPBackend{cfg: cfg, cache: map[string]httpCacheEntry{}}
+}
+
+// Metrics returns a snapshot of this backend's request/cache counters.
+func (b *HTTPBackend) Metrics() HTTPMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.metrics
+}
+
+func (b *HTTPBackend) IsSigned(ctx context.Context, identity Identity) (bool, error) {
+	if identity.Email == "" {
+		return false, fmt.Errorf("identity has no email to check")
+	}
+
+	key := normalizeEmail(identity.Email)
+
+	if b.cfg.CacheTTL > 0 {
+		if signed, ok := b.fromCache(key); ok {
+			return signed, nil
+		}
+	}
+
+	signed, err := b.fetch(ctx, identity.Email)
+	if err != nil {
+		return false, err
+	}
+
+	if b.cfg.CacheTTL > 0 {
+		b.toCache(key, signed)
+	}
+
+	return signed, nil
+}
+
+func (b *HTTPBackend) fromCache(key string) (bool, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.cache[key]
+	if !ok || time.Now().After(e.expires) {
+		return false, false
+	}
+
+	b.metrics.CacheHits++
+
+	return e.signed, true
+}
+
+func (b *HTTPBackend) toCache(key string, signed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cache[key] = httpCacheEntry{signed: signed, expires: time.Now().Add(b.cfg.CacheTTL)}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (b *HTTPBackend) fetch(ctx context.Context, email string) (bool, error) {
+	endpoint := fmt.Sprintf("%s?email=%s", b.cfg.URL, email)
+
+	var lastErr error
+	attempts := 0
+
+	for attempt := 1; attempt <= b.cfg.MaxAttempts; attempt++ {
+		attempts = attempt
+
+		signed, retryable, retryAfter, err := b.doRequest(ctx, endpoint)
+		if err == nil {
+			return signed, nil
+		}
+
+		lastErr = err
+
+		if !retryable || attempt == b.cfg.MaxAttempts {
+			break
+		}
+
+		if err := sleepCtx(ctx, backoffDelay(attempt, retryAfter)); err != nil {
+			return false, err
+		}
+	}
+
+	return false, fmt.Errorf("giving up after %d attempts, last error: %s", attempts, lastErr.Error())
+}
+
+func (b *HTTPBackend) doRequest(ctx context.Context, endpoint string) (signed, retryable bool, retryAfter time.Duration, err error) {
+	b.mu.Lock()
+	b.metrics.Requests++
+	b.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, false, 0, err
+	}
+
+	start := time.Now()
+	resp, doErr := b.cfg.Client.Do(req)
+
+	b.mu.Lock()
+	b.metrics.TotalLatency += time.Since(start)
+	b.mu.Unlock()
+
+	if doErr != nil {
+		return false, true, 0, doErr
+	}
+	defer resp.Body.Close()
+
+	rb, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, true, 0, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return false, true, parseRetryAfter(resp.Header.Get("Retry-After")),
+			fmt.Errorf("response has status %q and body %q", resp.Status, string(rb))
+
+	case resp.StatusCode >= 500:
+		return false, true, 0, fmt.Errorf("response has status %q and body %q", resp.Status, string(rb))
+
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return false, false, 0, fmt.Errorf("response has status %q and body %q", resp.Status, string(rb))
+	}
+
+	type signingInfo struct {
+		Signed bool `json:"signed"`
+	}
+	var v struct {
+		Data signingInfo `json:"data"`
+	}
+
+	if err := json.Unmarshal(rb, &v); err != nil {
+		return false, false, 0, fmt.Errorf("unmarshal failed: %s", err.Error())
+	}
+
+	return v.Data.Signed, false, 0, nil
+}
+
+func parseRetryAfter(h string) time.Duration {
+	if h == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(h); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(h); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+func backoffDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	base := time.Duration(1<<uint(attempt-1)) * 200 * time.Millisecond
+
+	return base + time.Duration(rand.Int63n(int64(base)+1))
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
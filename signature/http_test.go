@@ -0,0 +1,44 @@
+package signature
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"empty header", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"invalid value", "not-a-duration", 0},
+		{"http-date in the past", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := parseRetryAfter(c.header); got != c.want {
+				t.Fatalf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	if got := backoffDelay(1, 5*time.Second); got != 5*time.Second {
+		t.Fatalf("Retry-After should take precedence, got %s", got)
+	}
+
+	d := backoffDelay(1, 0)
+	if d < 200*time.Millisecond || d > 400*time.Millisecond {
+		t.Fatalf("attempt 1 backoff out of expected range: %s", d)
+	}
+
+	d2 := backoffDelay(3, 0)
+	if d2 < 800*time.Millisecond || d2 > 1600*time.Millisecond {
+		t.Fatalf("attempt 3 backoff out of expected range: %s", d2)
+	}
+}
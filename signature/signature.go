@@ -0,0 +1,31 @@
+// Package signature provides pluggable backends for deciding whether a contributor
+// has signed the CLA. Different repositories verify signatures differently: some run
+// an external HTTP signing service, some keep a signers file in a GitHub repo, and
+// some simply trust any contributor from an already-approved company domain. The
+// Backend interface lets botConfig pick the right one per repository.
+package signature
+
+import "context"
+
+// Identity describes the author of a commit being checked against a CLA backend.
+// Backends match on whichever fields they need: HTTPBackend matches by email,
+// GitHubUsernamesBackend matches by GitHub login, and CorporateCLABackend matches
+// by the email's domain.
+type Identity struct {
+	Email string
+	Login string
+	Name  string
+	SHA   string
+}
+
+// Backend decides whether an Identity has signed the CLA.
+type Backend interface {
+	IsSigned(ctx context.Context, identity Identity) (bool, error)
+}
+
+// MetricsProvider is implemented by Backends that expose observability
+// counters, currently just HTTPBackend. Callers should type-assert a Backend
+// against this interface rather than assume every backend reports metrics.
+type MetricsProvider interface {
+	Metrics() HTTPMetrics
+}
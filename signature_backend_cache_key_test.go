@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestSignatureBackendCacheKeyCorporateDiffersByApprovedDomains(t *testing.T) {
+	a := &botConfig{
+		BackendType:  signatureBackendCorporateCLA,
+		CorporateCLA: corporateCLAConfig{ApprovedDomains: map[string][]string{"example.com": {"alice"}}},
+	}
+	b := &botConfig{
+		BackendType:  signatureBackendCorporateCLA,
+		CorporateCLA: corporateCLAConfig{ApprovedDomains: map[string][]string{"example.com": {"bob"}}},
+	}
+
+	if signatureBackendCacheKey(a) == signatureBackendCacheKey(b) {
+		t.Fatalf("corporate configs with different approved domains must not share a cache key")
+	}
+}
+
+func TestSignatureBackendCacheKeyHTTPDiffersByTuning(t *testing.T) {
+	a := &botConfig{CheckURL: "https://cla.example.com"}
+	b := &botConfig{CheckURL: "https://cla.example.com", CheckRetries: 5}
+
+	if signatureBackendCacheKey(a) == signatureBackendCacheKey(b) {
+		t.Fatalf("http configs with the same URL but different retry tuning must not share a cache key")
+	}
+}
+
+func TestApprovedDomainsKeyIsOrderIndependent(t *testing.T) {
+	a := approvedDomainsKey(map[string][]string{"example.com": {"alice", "bob"}, "acme.com": nil})
+	b := approvedDomainsKey(map[string][]string{"acme.com": nil, "example.com": {"bob", "alice"}})
+
+	if a != b {
+		t.Fatalf("key must not depend on map/slice iteration order: %q != %q", a, b)
+	}
+}
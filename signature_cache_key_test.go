@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/opensourceways/robot-github-cla/signature"
+)
+
+func TestSignatureCacheKey(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       *botConfig
+		identity  signature.Identity
+		wantKey   string
+		wantCheck bool
+	}{
+		{
+			name:      "http backend keys on a valid email",
+			cfg:       &botConfig{},
+			identity:  signature.Identity{Email: "a@example.com", Login: "a"},
+			wantKey:   "a@example.com",
+			wantCheck: true,
+		},
+		{
+			name:      "http backend rejects an invalid email",
+			cfg:       &botConfig{},
+			identity:  signature.Identity{Email: "not-an-email", Login: "a"},
+			wantKey:   "not-an-email",
+			wantCheck: false,
+		},
+		{
+			name:      "github-usernames backend keys on login, not email",
+			cfg:       &botConfig{BackendType: signatureBackendGitHubUsers},
+			identity:  signature.Identity{Email: "a@example.com"},
+			wantKey:   "",
+			wantCheck: false,
+		},
+		{
+			name:      "github-usernames backend checkable with a login and no email",
+			cfg:       &botConfig{BackendType: signatureBackendGitHubUsers},
+			identity:  signature.Identity{Login: "a"},
+			wantKey:   "a",
+			wantCheck: true,
+		},
+		{
+			name:      "corporate backend keys on email, not login",
+			cfg:       &botConfig{BackendType: signatureBackendCorporateCLA},
+			identity:  signature.Identity{Login: "a"},
+			wantKey:   "",
+			wantCheck: false,
+		},
+		{
+			name:      "corporate backend checkable with an email and no login",
+			cfg:       &botConfig{BackendType: signatureBackendCorporateCLA},
+			identity:  signature.Identity{Email: "a@example.com"},
+			wantKey:   "a@example.com",
+			wantCheck: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			key, checkable := signatureCacheKey(c.cfg, c.identity)
+
+			if key != c.wantKey || checkable != c.wantCheck {
+				t.Fatalf("got (%q, %v), want (%q, %v)", key, checkable, c.wantKey, c.wantCheck)
+			}
+		})
+	}
+}
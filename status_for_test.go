@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatusFor(t *testing.T) {
+	cases := []struct {
+		name                         string
+		claPassed, dcoPassed, triage bool
+		wantState, wantDescSubstring string
+	}{
+		{"needs triage wins over everything", false, false, true, "pending", "label"},
+		{"both pass", true, true, false, "success", "CLA"},
+		{"cla fails", false, true, false, "failure", "CLA"},
+		{"dco fails", true, false, false, "failure", "DCO"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state, desc := statusFor(c.claPassed, c.dcoPassed, c.triage)
+
+			if state != c.wantState {
+				t.Fatalf("got state %q, want %q", state, c.wantState)
+			}
+
+			if !strings.Contains(desc, c.wantDescSubstring) {
+				t.Fatalf("got desc %q, want it to mention %q", desc, c.wantDescSubstring)
+			}
+		})
+	}
+}